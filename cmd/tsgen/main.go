@@ -0,0 +1,63 @@
+// Command tsgen generates a Chroma XML lexer from a tree-sitter grammar
+// checkout's highlights.scm and node-types.json.
+//
+// Usage:
+//
+//	tsgen --grammar ~/src/tree-sitter-nu --name Nu --alias nu \
+//	    --filename '*.nu' --mime text/plain --out ../../lexers/embedded/nu.xml
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/alecthomas/kong"
+
+	"github.com/alecthomas/chroma/v2/tsgen"
+)
+
+var cli struct {
+	Grammar  string   `help:"Path to a tree-sitter-<lang> checkout containing highlights.scm and node-types.json." required:""`
+	Name     string   `help:"Lexer name, e.g. Nu." required:""`
+	Alias    []string `help:"Lexer alias(es), e.g. --alias nu." required:""`
+	Filename []string `help:"Filename glob(s) this lexer should match, e.g. --filename '*.nu'." required:""`
+	Mime     []string `help:"MIME type(s) this lexer should match."`
+	Mapping  string   `help:"Path to a YAML file overriding capture-name-to-TokenType mappings."`
+	Out      string   `help:"Output path for the generated XML." required:""`
+}
+
+func main() {
+	kctx := kong.Parse(&cli, kong.Description("Generate a Chroma lexer from a tree-sitter grammar."))
+
+	grammar, err := tsgen.Load(cli.Grammar)
+	kctx.FatalIfErrorf(err)
+
+	mapping := tsgen.DefaultCaptureMapping
+	if cli.Mapping != "" {
+		mapping, err = tsgen.LoadOverrides(cli.Mapping, mapping)
+		kctx.FatalIfErrorf(err)
+	}
+
+	result, err := tsgen.Generate(grammar, mapping, tsgen.Options{
+		Name:      cli.Name,
+		Aliases:   cli.Alias,
+		Filenames: cli.Filename,
+		MimeTypes: cli.Mime,
+	})
+	kctx.FatalIfErrorf(err)
+
+	out, err := tsgen.Marshal(result.Lexer)
+	kctx.FatalIfErrorf(err)
+
+	err = os.WriteFile(cli.Out, out, 0644)
+	kctx.FatalIfErrorf(err)
+
+	fmt.Fprintf(os.Stderr, "wrote %s\n", cli.Out)
+	if len(result.Warnings) > 0 {
+		fmt.Fprintf(os.Stderr, "\n%d capture(s) need a hand-written rule:\n", len(result.Warnings))
+		for _, w := range result.Warnings {
+			fmt.Fprintf(os.Stderr, "  - %s\n", strings.TrimSpace(w))
+		}
+	}
+}
@@ -0,0 +1,432 @@
+package formatters
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/mattn/go-isatty"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/lexers"
+)
+
+// ColorProfile is how many colours a terminal can render, cheapest first.
+type ColorProfile int
+
+const (
+	// Mono emits no ANSI escape codes at all, not even bold/italic/underline.
+	Mono ColorProfile = iota
+	ANSI16
+	ANSI256
+	TrueColor
+)
+
+// DetectColorProfile guesses the terminal's colour depth the same way most
+// TUI libraries do: Mono on a pipe; on a TTY, COLORTERM wins when it says
+// truecolor/24bit, otherwise ANSI256 when TERM mentions 256color and ANSI16
+// for any other TTY.
+func DetectColorProfile() ColorProfile {
+	if !isatty.IsTerminal(os.Stdout.Fd()) && !isatty.IsCygwinTerminal(os.Stdout.Fd()) {
+		return Mono
+	}
+	switch strings.ToLower(os.Getenv("COLORTERM")) {
+	case "truecolor", "24bit":
+		return TrueColor
+	}
+	if term := os.Getenv("TERM"); strings.Contains(term, "256color") {
+		return ANSI256
+	}
+	return ANSI16
+}
+
+// Background is the terminal's presumed background brightness, used to
+// pick a readable palette variant the way `ls --color` or glamour do.
+type Background int
+
+const (
+	Dark Background = iota
+	Light
+)
+
+// DetectBackground reads COLORFGBG (set by many terminal emulators as
+// "fg;bg" palette indices) and classifies the background as Light when its
+// index suggests a pale colour, Dark otherwise - including when the
+// variable isn't set, since dark terminals are the more common default.
+func DetectBackground() Background {
+	fgbg := os.Getenv("COLORFGBG")
+	parts := strings.Split(fgbg, ";")
+	if len(parts) < 2 {
+		return Dark
+	}
+	bg, err := strconv.Atoi(strings.TrimSpace(parts[len(parts)-1]))
+	if err != nil {
+		return Dark
+	}
+	// The standard 16-colour palette's light entries (white and the
+	// bright variants) live at indices 7 and 15.
+	if bg == 7 || bg == 15 {
+		return Light
+	}
+	return Dark
+}
+
+// TTYMarkdownOption configures a TTYMarkdownFormatter.
+type TTYMarkdownOption func(*TTYMarkdownFormatter)
+
+// WithColorProfile overrides terminal colour-depth auto-detection.
+func WithColorProfile(p ColorProfile) TTYMarkdownOption {
+	return func(f *TTYMarkdownFormatter) { f.profile = p }
+}
+
+// WithBackground overrides terminal background auto-detection; this is
+// what a CLI's --background=dark|light flag should set.
+//
+// This tree has no cmd/chroma - the CLI that would own that flag isn't
+// part of this source snapshot, so there's nowhere here to add it. Wiring
+// WithBackground up to an actual --background flag is follow-up work for
+// whichever tree does carry cmd/chroma, not something this package can
+// deliver on its own.
+func WithBackground(b Background) TTYMarkdownOption {
+	return func(f *TTYMarkdownFormatter) { f.background = b }
+}
+
+// TTYMarkdownFormatter renders Markdown to ANSI, using a chroma.Style for
+// both fenced code blocks and prose elements (headings, blockquotes, list
+// bullets, links, tables), the way charmbracelet/glamour renders Markdown
+// against its own theme files.
+type TTYMarkdownFormatter struct {
+	profile    ColorProfile
+	background Background
+}
+
+// NewTTYMarkdown builds a TTYMarkdownFormatter, auto-detecting terminal
+// colour depth and background unless overridden with WithColorProfile /
+// WithBackground.
+func NewTTYMarkdown(options ...TTYMarkdownOption) *TTYMarkdownFormatter {
+	f := &TTYMarkdownFormatter{
+		profile:    DetectColorProfile(),
+		background: DetectBackground(),
+	}
+	for _, opt := range options {
+		opt(f)
+	}
+	return f
+}
+
+// TTYMarkdown is the formatter registered as "tty-markdown"; CLIs that want
+// a non-default colour profile or background should call NewTTYMarkdown
+// directly instead of going through the registry.
+var TTYMarkdown = NewTTYMarkdown()
+
+func init() {
+	Register("tty-markdown", TTYMarkdown)
+}
+
+// Format implements chroma.Formatter. iterator is expected to come from the
+// "markdown" lexer (or any lexer that tokenises its input as a single Text
+// run); Format reassembles the original source from it and renders that as
+// Markdown, rather than colourizing the tokens directly - unlike code
+// formatters, prose styling depends on block structure a regex lexer
+// doesn't recover.
+func (f *TTYMarkdownFormatter) Format(w io.Writer, style *chroma.Style, iterator chroma.Iterator) error {
+	var src strings.Builder
+	for token := iterator(); token != chroma.EOF; token = iterator() {
+		src.WriteString(token.Value)
+	}
+	return f.render(w, style, src.String())
+}
+
+var (
+	atxHeading  = regexp.MustCompile(`^(#{1,6})\s+(.*)$`)
+	fenceStart  = regexp.MustCompile("^```\\s*([a-zA-Z0-9_+-]*)\\s*$")
+	fenceEnd    = regexp.MustCompile("^```\\s*$")
+	blockquote  = regexp.MustCompile(`^>\s?(.*)$`)
+	bulletItem  = regexp.MustCompile(`^(\s*)[-*+]\s+(.*)$`)
+	orderedItem = regexp.MustCompile(`^(\s*)\d+\.\s+(.*)$`)
+	tableRow    = regexp.MustCompile(`^\s*\|(.+)\|\s*$`)
+	tableRule   = regexp.MustCompile(`^\s*\|?[\s:|-]+\|?\s*$`)
+)
+
+// isThematicBreak reports whether line is a Markdown thematic break: three
+// or more of the same marker character (-, * or _), optionally separated by
+// spaces. Go's RE2 regexp has no backreferences, so this can't be a single
+// pattern matching "whichever marker started the line" - walk the runes and
+// check uniformity by hand instead.
+func isThematicBreak(line string) bool {
+	marker := rune(0)
+	count := 0
+	for _, r := range strings.TrimSpace(line) {
+		if r == ' ' || r == '\t' {
+			continue
+		}
+		if r != '-' && r != '*' && r != '_' {
+			return false
+		}
+		if marker == 0 {
+			marker = r
+		} else if r != marker {
+			return false
+		}
+		count++
+	}
+	return count >= 3
+}
+
+func (f *TTYMarkdownFormatter) render(w io.Writer, style *chroma.Style, source string) error {
+	lines := strings.Split(source, "\n")
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+
+		switch {
+		case fenceStart.MatchString(line):
+			lang := fenceStart.FindStringSubmatch(line)[1]
+			var code []string
+			for i++; i < len(lines) && !fenceEnd.MatchString(lines[i]); i++ {
+				code = append(code, lines[i])
+			}
+			f.renderCodeBlock(w, style, lang, strings.Join(code, "\n"))
+
+		case atxHeading.MatchString(line):
+			m := atxHeading.FindStringSubmatch(line)
+			f.renderHeading(w, style, len(m[1]), m[2])
+
+		case blockquote.MatchString(line):
+			m := blockquote.FindStringSubmatch(line)
+			f.renderBlockquote(w, style, m[1])
+
+		case isThematicBreak(line):
+			fmt.Fprintln(w, f.sgr(style, chroma.Comment)+strings.Repeat("─", 3)+f.reset())
+
+		case tableRow.MatchString(line) && i+1 < len(lines) && tableRule.MatchString(lines[i+1]):
+			i = f.renderTable(w, style, lines, i)
+
+		case bulletItem.MatchString(line):
+			m := bulletItem.FindStringSubmatch(line)
+			fmt.Fprintf(w, "%s%s•%s %s\n", m[1], f.sgr(style, chroma.Punctuation), f.reset(), f.renderInline(style, m[2]))
+
+		case orderedItem.MatchString(line):
+			m := orderedItem.FindStringSubmatch(line)
+			fmt.Fprintf(w, "%s%s\n", m[1], f.renderInline(style, m[2]))
+
+		case strings.TrimSpace(line) == "":
+			fmt.Fprintln(w)
+
+		default:
+			fmt.Fprintln(w, f.renderInline(style, line))
+		}
+	}
+	return nil
+}
+
+func (f *TTYMarkdownFormatter) renderHeading(w io.Writer, style *chroma.Style, level int, text string) {
+	prefix := strings.Repeat("#", level)
+	fmt.Fprintf(w, "%s%s%s %s%s\n", f.sgr(style, chroma.GenericHeading), f.bold(), prefix, f.renderInline(style, text), f.reset())
+}
+
+func (f *TTYMarkdownFormatter) renderBlockquote(w io.Writer, style *chroma.Style, text string) {
+	fmt.Fprintf(w, "%s▎ %s%s\n", f.sgr(style, chroma.Comment), f.renderInline(style, text), f.reset())
+}
+
+func (f *TTYMarkdownFormatter) renderCodeBlock(w io.Writer, style *chroma.Style, lang, code string) {
+	lexer := lexers.Fallback
+	if lang != "" {
+		if l := lexers.Get(lang); l != nil {
+			lexer = l
+		}
+	}
+	iterator, err := lexer.Tokenise(nil, code)
+	if err != nil {
+		fmt.Fprintln(w, code)
+		return
+	}
+	for token := iterator(); token != chroma.EOF; token = iterator() {
+		fmt.Fprintf(w, "%s%s%s", f.sgr(style, token.Type), token.Value, f.reset())
+	}
+	fmt.Fprintln(w)
+}
+
+func (f *TTYMarkdownFormatter) renderTable(w io.Writer, style *chroma.Style, lines []string, start int) int {
+	var rows [][]string
+	i := start
+	for ; i < len(lines) && tableRow.MatchString(lines[i]); i++ {
+		if i == start+1 {
+			continue // separator row
+		}
+		cells := strings.Split(strings.Trim(lines[i], " |"), "|")
+		for c := range cells {
+			cells[c] = strings.TrimSpace(cells[c])
+		}
+		rows = append(rows, cells)
+	}
+	for r, row := range rows {
+		rendered := make([]string, len(row))
+		for c, cell := range row {
+			rendered[c] = f.renderInline(style, cell)
+		}
+		sep := f.sgr(style, chroma.Punctuation) + "│" + f.reset()
+		fmt.Fprintf(w, "%s %s %s\n", sep, strings.Join(rendered, " "+sep+" "), sep)
+		if r == 0 {
+			fmt.Fprintln(w, f.sgr(style, chroma.Punctuation)+strings.Repeat("─", 3)+f.reset())
+		}
+	}
+	return i - 1
+}
+
+var (
+	boldText   = regexp.MustCompile(`\*\*([^*]+)\*\*`)
+	italicText = regexp.MustCompile(`\*([^*]+)\*|_([^_]+)_`)
+	codeSpan   = regexp.MustCompile("`([^`]+)`")
+	linkText   = regexp.MustCompile(`\[([^\]]*)\]\(([^)]*)\)`)
+)
+
+// inlineSpan is one of renderInline's span kinds: re finds the next
+// occurrence of it in the remaining text, and render turns that match's
+// captured groups into its styled replacement.
+type inlineSpan struct {
+	re     *regexp.Regexp
+	render func(f *TTYMarkdownFormatter, style *chroma.Style, groups []string) string
+}
+
+var inlineSpans = []inlineSpan{
+	{linkText, func(f *TTYMarkdownFormatter, style *chroma.Style, groups []string) string {
+		return f.sgr(style, chroma.NameAttribute) + f.underline() + groups[1] + f.reset()
+	}},
+	{codeSpan, func(f *TTYMarkdownFormatter, style *chroma.Style, groups []string) string {
+		return f.sgr(style, chroma.LiteralString) + groups[1] + f.reset()
+	}},
+	{boldText, func(f *TTYMarkdownFormatter, style *chroma.Style, groups []string) string {
+		return f.bold() + groups[1] + f.reset()
+	}},
+	{italicText, func(f *TTYMarkdownFormatter, style *chroma.Style, groups []string) string {
+		inner := groups[1]
+		if inner == "" {
+			inner = groups[2]
+		}
+		return f.italic() + inner + f.reset()
+	}},
+}
+
+// renderInline applies **bold**, *italic*, `code` and [text](url) styling
+// within a single line of prose. It scans left to right picking, at each
+// point, whichever span starts soonest - once a span is consumed, later
+// spans only ever see the text after it, so a code span's literal content
+// (which may itself contain "**" or "_") can't be reinterpreted as bold or
+// italic the way four independent global substitutions would.
+func (f *TTYMarkdownFormatter) renderInline(style *chroma.Style, text string) string {
+	var out strings.Builder
+	for text != "" {
+		var (
+			bestLoc  []int
+			bestSpan inlineSpan
+		)
+		for _, span := range inlineSpans {
+			loc := span.re.FindStringSubmatchIndex(text)
+			if loc == nil {
+				continue
+			}
+			if bestLoc == nil || loc[0] < bestLoc[0] {
+				bestLoc, bestSpan = loc, span
+			}
+		}
+		if bestLoc == nil {
+			out.WriteString(text)
+			break
+		}
+		out.WriteString(text[:bestLoc[0]])
+		out.WriteString(bestSpan.render(f, style, submatches(text, bestLoc)))
+		text = text[bestLoc[1]:]
+	}
+	return out.String()
+}
+
+// submatches turns a FindStringSubmatchIndex result into the matched text
+// for the full match and each group (empty string for a group the
+// alternation didn't take, e.g. italicText's unused "_..._" group).
+func submatches(text string, loc []int) []string {
+	groups := make([]string, len(loc)/2)
+	for i := range groups {
+		if s, e := loc[2*i], loc[2*i+1]; s >= 0 {
+			groups[i] = text[s:e]
+		}
+	}
+	return groups
+}
+
+func (f *TTYMarkdownFormatter) bold() string      { return f.esc("1") }
+func (f *TTYMarkdownFormatter) italic() string    { return f.esc("3") }
+func (f *TTYMarkdownFormatter) underline() string { return f.esc("4") }
+func (f *TTYMarkdownFormatter) reset() string     { return f.esc("0") }
+
+func (f *TTYMarkdownFormatter) esc(code string) string {
+	if f.profile == Mono {
+		return ""
+	}
+	return "\x1b[" + code + "m"
+}
+
+// sgr returns the foreground colour escape for tt as defined by style,
+// nudged for f.background if it would otherwise be unreadable, and encoded
+// for the formatter's detected (or overridden) colour profile.
+func (f *TTYMarkdownFormatter) sgr(style *chroma.Style, tt chroma.TokenType) string {
+	if f.profile == Mono {
+		return ""
+	}
+	entry := style.Get(tt)
+	if !entry.Colour.IsSet() {
+		return ""
+	}
+	r, g, b := f.forBackground(entry.Colour)
+	switch f.profile {
+	case TrueColor:
+		return fmt.Sprintf("\x1b[38;2;%d;%d;%dm", r, g, b)
+	case ANSI256:
+		return fmt.Sprintf("\x1b[38;5;%dm", ansi256(r, g, b))
+	default:
+		return fmt.Sprintf("\x1b[38;5;%dm", ansi16(r, g, b))
+	}
+}
+
+// forBackground returns c's channels, scaled towards black or white when
+// f.background would otherwise swallow it - most chroma styles are tuned
+// for a dark terminal, so a near-white token on a light background (or a
+// near-black one on a dark background) needs pulling back towards the
+// middle of the range to stay legible.
+func (f *TTYMarkdownFormatter) forBackground(c chroma.Colour) (r, g, b int) {
+	r, g, b = int(c.Red()), int(c.Green()), int(c.Blue())
+	const lumaLow, lumaHigh = 60, 195
+	luma := (r*299 + g*587 + b*114) / 1000
+	switch {
+	case f.background == Light && luma > lumaHigh:
+		r, g, b = r*2/3, g*2/3, b*2/3
+	case f.background == Dark && luma < lumaLow:
+		r, g, b = r+(255-r)/2, g+(255-g)/2, b+(255-b)/2
+	}
+	return r, g, b
+}
+
+// ansi256 maps a 24-bit colour onto the 6x6x6 colour cube of the 256-colour
+// palette (indices 16-231).
+func ansi256(r, g, b int) int {
+	return 16 + 36*(r*5/255) + 6*(g*5/255) + b*5/255
+}
+
+// ansi16 maps a 24-bit colour onto the nearest of the 8 standard ANSI
+// foreground colours (30-37, returned here as their 256-colour aliases
+// 0-7) by picking the dominant channel(s).
+func ansi16(r, g, b int) int {
+	idx := 0
+	if r > 127 {
+		idx |= 1
+	}
+	if g > 127 {
+		idx |= 2
+	}
+	if b > 127 {
+		idx |= 4
+	}
+	return idx
+}
@@ -0,0 +1,58 @@
+package formatters
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/alecthomas/chroma/v2/styles"
+)
+
+func TestTTYMarkdownMono(t *testing.T) {
+	f := NewTTYMarkdown(WithColorProfile(Mono))
+	style := styles.Get("monokai")
+
+	const src = "# Title\n\nSome **bold** and *italic* text with `code`.\n\n- one\n- two\n"
+
+	it, err := lexers.Get("markdown").Tokenise(nil, src)
+	if err != nil {
+		t.Fatalf("Tokenise: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := f.Format(&buf, style, it); err != nil {
+		t.Fatalf("Format: %v", err)
+	}
+
+	got := buf.String()
+	for _, want := range []string{"# Title", "bold", "italic", "code", "• one", "• two"} {
+		if !bytes.Contains([]byte(got), []byte(want)) {
+			t.Errorf("output missing %q, got:\n%s", want, got)
+		}
+	}
+	if bytes.Contains([]byte(got), []byte("\x1b[")) {
+		t.Errorf("Mono profile should emit no escape codes, got:\n%s", got)
+	}
+}
+
+func TestDetectBackground(t *testing.T) {
+	tests := []struct {
+		colorfgbg string
+		want      Background
+	}{
+		{"", Dark},
+		{"15;0", Dark},
+		{"0;15", Light},
+		{"0;7", Light},
+		{"not-a-number", Dark},
+	}
+	for _, tt := range tests {
+		t.Setenv("COLORFGBG", tt.colorfgbg)
+		if got := DetectBackground(); got != tt.want {
+			t.Errorf("DetectBackground() with COLORFGBG=%q = %v, want %v", tt.colorfgbg, got, tt.want)
+		}
+	}
+}
+
+var _ chroma.Formatter = (*TTYMarkdownFormatter)(nil)
@@ -0,0 +1,140 @@
+package lexers_test
+
+import (
+	"embed"
+	"testing"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/lexers"
+)
+
+//go:embed testdata/nu/*.nu
+var nuFixtures embed.FS
+
+// TestNuLexer exercises the hand-added cell-path, record, table, closure
+// and block-comment rules, plus the "$\"" interpolation trigger that never
+// matched while its pattern held literal entity text. The shebang rule's
+// move out of interpolation's reach has no fixture here.
+func TestNuLexer(t *testing.T) {
+	tests := []struct {
+		fixture string
+		want    []wantToken
+	}{
+		{
+			fixture: "cell_path.nu",
+			want: []wantToken{
+				{chroma.NameVariable, "$env"},
+				{chroma.Punctuation, "."},
+				{chroma.NameAttribute, "PATH"},
+				{chroma.Punctuation, "."},
+				{chroma.LiteralNumber, "0"},
+				{chroma.Punctuation, "."},
+				{chroma.NameAttribute, "name"},
+			},
+		},
+		{
+			fixture: "record.nu",
+			want: []wantToken{
+				{chroma.NameAttribute, "name"},
+				{chroma.NameAttribute, "version"},
+				{chroma.LiteralNumber, "1"},
+			},
+		},
+		{
+			fixture: "table.nu",
+			want: []wantToken{
+				{chroma.Punctuation, "[["},
+				{chroma.NameAttribute, "a"},
+				{chroma.NameAttribute, "b"},
+				{chroma.Punctuation, "]"},
+			},
+		},
+		{
+			// Negative case for the table_header push: a plain nested list
+			// also starts with "[[", but holds numbers rather than a
+			// bare-identifier header row, so it must never enter
+			// table_header (whose rules have nothing for digits or commas
+			// and would otherwise fall back to Error tokens).
+			fixture: "list.nu",
+			want: []wantToken{
+				{chroma.Punctuation, "["},
+				{chroma.LiteralNumber, "1"},
+				{chroma.Punctuation, ","},
+				{chroma.LiteralNumber, "2"},
+				{chroma.Punctuation, "]"},
+				{chroma.LiteralNumber, "3"},
+				{chroma.LiteralNumber, "4"},
+			},
+		},
+		{
+			fixture: "closure.nu",
+			want: []wantToken{
+				{chroma.Punctuation, "{"},
+				{chroma.Punctuation, "|"},
+				{chroma.NameVariable, "x"},
+				{chroma.Punctuation, ","},
+				{chroma.NameVariable, "y"},
+				{chroma.Punctuation, "|"},
+				{chroma.NameVariable, "$x"},
+				{chroma.NameVariable, "$y"},
+			},
+		},
+		{
+			fixture: "comment_block.nu",
+			want: []wantToken{
+				{chroma.CommentMultiline, "#| block comment |#"},
+			},
+		},
+		{
+			fixture: "interpolation.nu",
+			want: []wantToken{
+				{chroma.LiteralStringDouble, `$"`},
+				{chroma.LiteralStringInterpol, "("},
+				{chroma.LiteralStringInterpol, ")"},
+			},
+		},
+	}
+
+	lexer := lexers.Get("nu")
+	if lexer == nil {
+		t.Fatal("no lexer registered for \"nu\"")
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			source, err := nuFixtures.ReadFile("testdata/nu/" + tt.fixture)
+			if err != nil {
+				t.Fatalf("reading fixture: %v", err)
+			}
+
+			it, err := lexer.Tokenise(nil, string(source))
+			if err != nil {
+				t.Fatalf("Tokenise: %v", err)
+			}
+
+			var got []wantToken
+			for tok := it(); tok != chroma.EOF; tok = it() {
+				got = append(got, wantToken{tok.Type, tok.Value})
+			}
+
+			for _, w := range tt.want {
+				found := false
+				for _, g := range got {
+					if g.tt == w.tt && g.value == w.value {
+						found = true
+						break
+					}
+				}
+				if !found {
+					t.Errorf("%s: expected token {%v, %q} not found in output %v", tt.fixture, w.tt, w.value, got)
+				}
+			}
+
+			for _, g := range got {
+				if g.tt == chroma.Error {
+					t.Errorf("%s: got Error token %q, lexer has no rule matching it: %v", tt.fixture, g.value, got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package lexers_test
+
+import (
+	"testing"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/lexers"
+)
+
+// These lexers were originally written against dedicated NameDirective /
+// NameMatcher / NamePlaceholder token types, but this tree has no types.go
+// defining chroma.TokenType's stringer, so there's nowhere to register new
+// constants without them panicking as "not a TokenType value" the moment
+// anything renders them. They now alias the closest existing generic types
+// (NameBuiltin / NameLabel / NameVariable respectively) instead; treat that
+// as the real, final scope of structured-config highlighting here, not a
+// stopgap pending a types.go that would let the dedicated types come back.
+
+// wantToken is one (TokenType, Value) pair a lexer's output must contain
+// somewhere among its other tokens; the order of wanted tokens isn't checked.
+type wantToken struct {
+	tt    chroma.TokenType
+	value string
+}
+
+func TestStructuredConfigSubtypes(t *testing.T) {
+	tests := []struct {
+		lexer  string
+		source string
+		want   []wantToken
+	}{
+		{
+			lexer:  "caddyfile-directives",
+			source: "example.com {\n  @api path /api/*\n  reverse_proxy {env.BACKEND}\n}\n",
+			want: []wantToken{
+				{chroma.NameLabel, "@api"},
+				{chroma.NameBuiltin, "reverse_proxy"},
+				{chroma.NameVariable, "{env.BACKEND}"},
+			},
+		},
+		{
+			lexer:  "hcl2",
+			source: "resource \"aws_instance\" \"web\" {\n  ami = \"${var.ami}\"\n}\n",
+			want: []wantToken{
+				{chroma.NameBuiltin, "resource"},
+				{chroma.LiteralStringInterpol, "${"},
+			},
+		},
+		{
+			lexer:  "nginx-directives",
+			source: "location ~ \\.php$ {\n  proxy_pass http://$backend;\n}\n",
+			want: []wantToken{
+				{chroma.NameBuiltin, "location"},
+				{chroma.NameLabel, "~"},
+				{chroma.NameBuiltin, "proxy_pass"},
+				{chroma.NameVariable, "$backend"},
+			},
+		},
+		{
+			lexer:  "systemd-unit",
+			source: "[Service]\nExecStart=/usr/bin/app --id=%i\n",
+			want: []wantToken{
+				{chroma.KeywordNamespace, "[Service]"},
+				{chroma.NameBuiltin, "ExecStart"},
+				{chroma.NameVariable, "%i"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.lexer, func(t *testing.T) {
+			lexer := lexers.Get(tt.lexer)
+			if lexer == nil {
+				t.Fatalf("no lexer registered for %q", tt.lexer)
+			}
+			it, err := lexer.Tokenise(nil, tt.source)
+			if err != nil {
+				t.Fatalf("Tokenise: %v", err)
+			}
+
+			var got []wantToken
+			for tok := it(); tok != chroma.EOF; tok = it() {
+				got = append(got, wantToken{tok.Type, tok.Value})
+			}
+
+			for _, w := range tt.want {
+				found := false
+				for _, g := range got {
+					if g.tt == w.tt && g.value == w.value {
+						found = true
+						break
+					}
+				}
+				if !found {
+					t.Errorf("%s: expected token {%v, %q} not found in output %v", tt.lexer, w.tt, w.value, got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+//go:build treesitter
+
+package lexers
+
+import (
+	sitter "github.com/smacker/go-tree-sitter"
+
+	"github.com/alecthomas/chroma/v2"
+)
+
+// RegisterTreeSitter builds a chroma.TreeSitterLexer from grammar and
+// highlightsSCM and registers it the same way Register does for a
+// RegexLexer, so lookups by alias/filename/mime type work unchanged for
+// callers that don't care which implementation backs a given language.
+func RegisterTreeSitter(config *chroma.Config, grammar *sitter.Language, highlightsSCM string, mapping chroma.TreeSitterMapping, options ...chroma.TreeSitterOption) chroma.Lexer {
+	lexer, err := chroma.NewTreeSitterLexer(config, grammar, highlightsSCM, mapping, options...)
+	if err != nil {
+		// Mirrors the rest of lexers/embedded registration: a malformed
+		// built-in grammar is a programming error, not a runtime one.
+		panic(err)
+	}
+	return Register(lexer)
+}
@@ -0,0 +1,105 @@
+//go:build treesitter
+
+package chroma
+
+import (
+	"context"
+	"fmt"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// TreeSitterLexer lexes by parsing text with a tree-sitter grammar and
+// walking the resulting syntax tree's highlights query, rather than by
+// running a sequence of regex states. It implements Lexer so it plugs into
+// the existing formatter/style pipeline unchanged.
+//
+// Use it for grammars that are hostile to regex-state lexing (whitespace
+// sensitive languages, nested interpolation, anything where "what state am
+// I in" depends on actual tree structure instead of the last few tokens
+// seen). For everything else RegexLexer remains simpler to read, debug and
+// ship without a cgo dependency.
+type TreeSitterLexer struct {
+	config   *Config
+	language *sitter.Language
+	query    *sitter.Query
+	mapping  TreeSitterMapping
+	analyse  func(text string) float32
+}
+
+// TreeSitterOption configures optional behaviour of a TreeSitterLexer.
+type TreeSitterOption func(*TreeSitterLexer)
+
+// WithAnalyser sets the function AnalyseText delegates to. Lexers
+// registered without one always report 0, i.e. they're never picked by
+// filename-ambiguous content sniffing.
+func WithAnalyser(fn func(text string) float32) TreeSitterOption {
+	return func(l *TreeSitterLexer) { l.analyse = fn }
+}
+
+// NewTreeSitterLexer compiles highlightsSCM against grammar and returns a
+// lexer that tokenises using it. highlightsSCM is the raw contents of the
+// grammar's highlights.scm, exactly as tsgen's generator consumes it -
+// mapping should normally come from the same tsgen.CaptureMapping
+// (DefaultCaptureMapping plus any overrides) used to generate the grammar's
+// regex lexer, so the two stay in sync.
+func NewTreeSitterLexer(config *Config, grammar *sitter.Language, highlightsSCM string, mapping TreeSitterMapping, options ...TreeSitterOption) (*TreeSitterLexer, error) {
+	query, err := sitter.NewQuery([]byte(highlightsSCM), grammar)
+	if err != nil {
+		return nil, fmt.Errorf("chroma: compiling tree-sitter query for %s: %w", config.Name, err)
+	}
+	l := &TreeSitterLexer{config: config, language: grammar, query: query, mapping: mapping}
+	for _, opt := range options {
+		opt(l)
+	}
+	return l, nil
+}
+
+// Config returns the lexer's Config.
+func (l *TreeSitterLexer) Config() *Config { return l.config }
+
+// AnalyseText reports how confident this lexer is that text is written in
+// its language, for filename-ambiguous content. It defers to the function
+// passed to WithAnalyser, or reports 0 if none was given.
+func (l *TreeSitterLexer) AnalyseText(text string) float32 {
+	if l.analyse == nil {
+		return 0
+	}
+	return l.analyse(text)
+}
+
+// Tokenise parses text with the tree-sitter grammar and turns each
+// highlights.scm capture into a Token, filling the gaps between captures
+// (and any captures with no mapped TokenType) with Text.
+func (l *TreeSitterLexer) Tokenise(options *TokeniseOptions, text string) (Iterator, error) {
+	parser := sitter.NewParser()
+	parser.SetLanguage(l.language)
+
+	source := []byte(text)
+	tree, err := parser.ParseCtx(context.Background(), nil, source)
+	if err != nil {
+		return nil, fmt.Errorf("chroma: tree-sitter parse of %s failed: %w", l.config.Name, err)
+	}
+	defer tree.Close()
+
+	cursor := sitter.NewQueryCursor()
+	defer cursor.Close()
+	cursor.Exec(l.query, tree.RootNode())
+
+	var spans []span
+	for {
+		match, ok := cursor.NextMatch()
+		if !ok {
+			break
+		}
+		for _, c := range match.Captures {
+			tt, ok := l.mapping[l.query.CaptureNameForId(c.Index)]
+			if !ok {
+				continue
+			}
+			spans = append(spans, span{start: c.Node.StartByte(), end: c.Node.EndByte(), tokenType: tt})
+		}
+	}
+
+	return Literator(mergeSpans(source, spans)...), nil
+}
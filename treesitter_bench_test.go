@@ -0,0 +1,83 @@
+//go:build treesitter
+
+package chroma_test
+
+import (
+	"os"
+	"testing"
+
+	sitter "github.com/smacker/go-tree-sitter"
+	tsnu "github.com/nushell/tree-sitter-nu/bindings/go"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/lexers"
+)
+
+// loadTestdataHighlights reads the highlights.scm fixture tsgen's golden
+// tests already use, so this benchmark exercises the same query the
+// generator saw rather than a second hand-copy that could drift.
+func loadTestdataHighlights(b *testing.B, grammar string) string {
+	b.Helper()
+	src, err := os.ReadFile("tsgen/testdata/" + grammar + "/highlights.scm")
+	if err != nil {
+		b.Fatalf("reading highlights.scm fixture: %v", err)
+	}
+	return string(src)
+}
+
+// sampleNuScript is deliberately small; the point of this benchmark is the
+// per-call overhead of parsing-and-walking a tree vs. running regex states,
+// not throughput on a particular file size.
+const sampleNuScript = `
+def greet [name: string] {
+    let message = $"Hello, ($name)!"
+    print $message
+}
+
+for $user in ["alice" "bob" "carol"] {
+    greet $user
+}
+`
+
+func newNuTreeSitterLexer(b *testing.B) *chroma.TreeSitterLexer {
+	b.Helper()
+	highlights := loadTestdataHighlights(b, "nu")
+	lexer, err := chroma.NewTreeSitterLexer(
+		&chroma.Config{Name: "Nu", Aliases: []string{"nu"}, Filenames: []string{"*.nu"}},
+		sitter.NewLanguage(tsnu.Language()),
+		highlights,
+		chroma.TreeSitterMapping{"keyword": chroma.Keyword, "string": chroma.LiteralString},
+	)
+	if err != nil {
+		b.Fatalf("NewTreeSitterLexer: %v", err)
+	}
+	return lexer
+}
+
+func BenchmarkTreeSitterLexer(b *testing.B) {
+	lexer := newNuTreeSitterLexer(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		it, err := lexer.Tokenise(nil, sampleNuScript)
+		if err != nil {
+			b.Fatal(err)
+		}
+		for token := it(); token != chroma.EOF; token = it() {
+		}
+	}
+}
+
+func BenchmarkRegexLexer(b *testing.B) {
+	lexer := lexers.Get("nu")
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		it, err := lexer.Tokenise(nil, sampleNuScript)
+		if err != nil {
+			b.Fatal(err)
+		}
+		for token := it(); token != chroma.EOF; token = it() {
+		}
+	}
+}
@@ -0,0 +1,10 @@
+package chroma
+
+// TreeSitterMapping maps a tree-sitter capture name (e.g. "function.builtin",
+// without its leading "@") to the TokenType a TreeSitterLexer should emit
+// for nodes captured under it.
+//
+// This type has no tree-sitter dependency of its own, unlike TreeSitterLexer,
+// so it stays available in pure-Go builds; it's what lets tsgen build a
+// mapping without pulling in cgo.
+type TreeSitterMapping map[string]TokenType
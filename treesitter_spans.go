@@ -0,0 +1,79 @@
+package chroma
+
+import "sort"
+
+// span is a byte range a tree-sitter capture assigned a TokenType to.
+// Spans may nest (an outer node and a child node both captured, e.g. a
+// whole string literal and an interpolated portion inside it) but are
+// never assumed to partially overlap - that can't happen for captures
+// read off a single syntax tree, where every node's range is either
+// disjoint from or fully contained in any other node's range.
+type span struct {
+	start, end uint32
+	tokenType  TokenType
+}
+
+// mergeSpans turns a set of (possibly nested) capture spans into a flat,
+// in-order token stream covering source end to end, filling any
+// uncaptured bytes with Text.
+//
+// A narrower span nested inside a wider one is spliced into it rather than
+// dropped: the wider span's token type still covers the bytes its nested
+// spans don't, split around them instead of being swallowed whole.
+func mergeSpans(source []byte, spans []span) []Token {
+	sort.Slice(spans, func(i, j int) bool {
+		if spans[i].start != spans[j].start {
+			return spans[i].start < spans[j].start
+		}
+		return spans[i].end > spans[j].end // widest (outermost) capture first
+	})
+
+	type node struct {
+		span
+		children []*node
+	}
+	var roots []*node
+	var open []*node // ancestor chain of the span currently being built
+	for _, s := range spans {
+		for len(open) > 0 && open[len(open)-1].end <= s.start {
+			open = open[:len(open)-1]
+		}
+		n := &node{span: s}
+		if len(open) > 0 {
+			parent := open[len(open)-1]
+			parent.children = append(parent.children, n)
+		} else {
+			roots = append(roots, n)
+		}
+		open = append(open, n)
+	}
+
+	var tokens []Token
+	var walk func(n *node)
+	walk = func(n *node) {
+		pos := n.start
+		for _, c := range n.children {
+			if c.start > pos {
+				tokens = append(tokens, Token{Type: n.tokenType, Value: string(source[pos:c.start])})
+			}
+			walk(c)
+			pos = c.end
+		}
+		if pos < n.end {
+			tokens = append(tokens, Token{Type: n.tokenType, Value: string(source[pos:n.end])})
+		}
+	}
+
+	pos := uint32(0)
+	for _, r := range roots {
+		if r.start > pos {
+			tokens = append(tokens, Token{Type: Text, Value: string(source[pos:r.start])})
+		}
+		walk(r)
+		pos = r.end
+	}
+	if pos < uint32(len(source)) {
+		tokens = append(tokens, Token{Type: Text, Value: string(source[pos:])})
+	}
+	return tokens
+}
@@ -0,0 +1,90 @@
+package chroma
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestMergeSpans is the correctness coverage Tokenise itself never had:
+// treesitter_bench_test.go only measures throughput against a real
+// grammar, so it would never notice a nested capture silently losing its
+// token type. These cases exercise mergeSpans directly, without a
+// tree-sitter parse, so they run in every build (mergeSpans has no cgo
+// dependency), not just //go:build treesitter ones.
+func TestMergeSpans(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		spans  []span
+		want   []Token
+	}{
+		{
+			name:   "no captures",
+			source: "plain text",
+			want:   []Token{{Type: Text, Value: "plain text"}},
+		},
+		{
+			name:   "single capture with gaps either side",
+			source: "a keyword b",
+			spans:  []span{{start: 2, end: 9, tokenType: Keyword}},
+			want: []Token{
+				{Type: Text, Value: "a "},
+				{Type: Keyword, Value: "keyword"},
+				{Type: Text, Value: " b"},
+			},
+		},
+		{
+			name:   "adjacent non-nested captures",
+			source: "foobar",
+			spans: []span{
+				{start: 0, end: 3, tokenType: NameFunction},
+				{start: 3, end: 6, tokenType: NameVariable},
+			},
+			want: []Token{
+				{Type: NameFunction, Value: "foo"},
+				{Type: NameVariable, Value: "bar"},
+			},
+		},
+		{
+			name:   "nested capture is spliced into its enclosing span",
+			source: `"a(name)c"`,
+			// @string spans the whole literal; @string.special spans just
+			// the "(name)" interpolation inside it - the motivating case
+			// from the request, e.g. nu's $"...(...)..." or HCL's "${...}".
+			spans: []span{
+				{start: 0, end: 10, tokenType: LiteralString},
+				{start: 2, end: 8, tokenType: LiteralStringInterpol},
+			},
+			want: []Token{
+				{Type: LiteralString, Value: `"a`},
+				{Type: LiteralStringInterpol, Value: "(name)"},
+				{Type: LiteralString, Value: `c"`},
+			},
+		},
+		{
+			name:   "doubly nested captures",
+			source: "a(b[c]d)e",
+			spans: []span{
+				{start: 0, end: 9, tokenType: LiteralString},
+				{start: 1, end: 8, tokenType: LiteralStringInterpol},
+				{start: 3, end: 6, tokenType: NameVariable},
+			},
+			want: []Token{
+				{Type: LiteralString, Value: "a"},
+				{Type: LiteralStringInterpol, Value: "(b"},
+				{Type: NameVariable, Value: "[c]"},
+				{Type: LiteralStringInterpol, Value: "d)"},
+				{Type: LiteralString, Value: "e"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mergeSpans([]byte(tt.source), tt.spans)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("mergeSpans(%q) = %v, want %v", tt.source, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+// Package tsgen turns a tree-sitter grammar (its `highlights.scm` query file
+// and `node-types.json`) into a Chroma XML lexer definition.
+//
+// It exists so that adding support for a new language doesn't mean hand
+// transcribing a highlights.scm into regex states by eye, the way the
+// original one-off Nu generator did. Given a grammar directory and a
+// capture-to-TokenType mapping, Generate produces the same Lexer/State/Rule
+// structure that lexers/embedded/*.xml files already use, so its output can
+// be written straight into that directory.
+//
+// The pipeline is intentionally conservative: it does not attempt to
+// understand the full tree-sitter grammar DSL in grammar.js. It only reads
+// the @capture annotations in highlights.scm (literal strings, bracketed
+// literal lists, and named nodes) plus node-types.json, which together are
+// enough to recover keywords, builtins, operators and punctuation into a
+// single flat root state. Generate does not synthesize the push/pop states
+// that stateful constructs (strings with escapes or interpolation, nested
+// comments) need - it has no reliable way to recover the delimiters and
+// transitions those states require from highlights.scm alone. Instead, when
+// node-types.json shows a captured node has children, Generate reports it
+// as a warning naming the child types, so those states can be hand-added
+// to the generated XML the same way lexers/embedded/nu.xml's were.
+package tsgen
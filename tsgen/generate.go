@@ -0,0 +1,131 @@
+package tsgen
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/alecthomas/chroma/v2"
+)
+
+// Options configures the lexer Config block Generate produces; everything
+// here comes from CLI flags rather than the grammar itself.
+type Options struct {
+	Name      string
+	Aliases   []string
+	Filenames []string
+	MimeTypes []string
+}
+
+// Result is a generated lexer plus anything Generate couldn't turn into a
+// rule on its own.
+type Result struct {
+	Lexer *Lexer
+	// Warnings lists captures that named a grammar node (e.g. "(string)
+	// @string") rather than a literal, which tsgen has no regex for.
+	// These need a hand-written rule added to the generated XML, the same
+	// way lexers/embedded files have always been edited.
+	Warnings []string
+}
+
+var wordLiteral = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// Generate builds a Chroma lexer from g's recovered captures, mapping each
+// capture name to a TokenType via mapping (falling back to chroma.Text for
+// captures mapping doesn't cover).
+func Generate(g *Grammar, mapping CaptureMapping, opts Options) (*Result, error) {
+	if opts.Name == "" {
+		return nil, fmt.Errorf("tsgen: Options.Name is required")
+	}
+
+	captureNames := make([]string, 0, len(g.LiteralsByCapture))
+	for name := range g.LiteralsByCapture {
+		captureNames = append(captureNames, name)
+	}
+	sort.Strings(captureNames)
+
+	var rules []Rule
+	for _, name := range captureNames {
+		tt, ok := mapping[name]
+		if !ok {
+			tt = chroma.Text
+		}
+		rules = append(rules, literalRule(g.LiteralsByCapture[name], tt))
+	}
+
+	rules = append(rules,
+		Rule{Pattern: `\s+`, Token: &Token{Type: chroma.TextWhitespace.String()}},
+		Rule{Pattern: `[^\s]+`, Token: &Token{Type: chroma.Text.String()}},
+	)
+
+	var warnings []string
+	nodeCaptureNames := make([]string, 0, len(g.NodeTypesByCapture))
+	for name := range g.NodeTypesByCapture {
+		nodeCaptureNames = append(nodeCaptureNames, name)
+	}
+	sort.Strings(nodeCaptureNames)
+	for _, name := range nodeCaptureNames {
+		for _, nodeType := range g.NodeTypesByCapture[name] {
+			if children := g.ChildTypes(nodeType); len(children) > 0 {
+				warnings = append(warnings, fmt.Sprintf(
+					"capture %q matches grammar node %q, which node-types.json gives child nodes %v; "+
+						"Generate only emits a flat root state, so this needs a hand-written push/pop "+
+						"state in the generated XML (see lexers/embedded/nu.xml's interpolated_string "+
+						"state for the shape one takes)", name, nodeType, children))
+				continue
+			}
+			warnings = append(warnings, fmt.Sprintf(
+				"capture %q matches grammar node %q with no literal pattern in highlights.scm; "+
+					"add a hand-written rule for it to the generated XML", name, nodeType))
+		}
+	}
+
+	lexer := &Lexer{
+		Config: Config{
+			Name:      opts.Name,
+			Aliases:   opts.Aliases,
+			Filenames: opts.Filenames,
+			MimeTypes: opts.MimeTypes,
+		},
+		Rules: Rules{
+			States: []State{
+				{Name: "root", Rules: rules},
+			},
+		},
+	}
+	return &Result{Lexer: lexer, Warnings: warnings}, nil
+}
+
+// literalRule builds a single alternation rule over literals, all mapped to
+// the same TokenType. Word-like literals ("let", "export-env") get \b
+// boundaries so they don't match inside longer identifiers; everything else
+// (operators, brackets) is joined as a bare alternation.
+func literalRule(literals []string, tt chroma.TokenType) Rule {
+	sorted := append([]string(nil), literals...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if len(sorted[i]) != len(sorted[j]) {
+			return len(sorted[i]) > len(sorted[j])
+		}
+		return sorted[i] < sorted[j]
+	})
+
+	var words, symbols []string
+	for _, lit := range sorted {
+		if wordLiteral.MatchString(lit) {
+			words = append(words, regexp.QuoteMeta(lit))
+		} else {
+			symbols = append(symbols, regexp.QuoteMeta(lit))
+		}
+	}
+
+	var alts []string
+	if len(words) > 0 {
+		alts = append(alts, `\b(`+strings.Join(words, "|")+`)\b`)
+	}
+	if len(symbols) > 0 {
+		alts = append(alts, "("+strings.Join(symbols, "|")+")")
+	}
+
+	return Rule{Pattern: strings.Join(alts, "|"), Token: &Token{Type: tt.String()}}
+}
@@ -0,0 +1,92 @@
+package tsgen_test
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alecthomas/chroma/v2/tsgen"
+)
+
+var update = flag.Bool("update", false, "write generated output back to testdata/*/golden.xml")
+
+func TestGenerateGolden(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       tsgen.Options
+		mappingYML string // relative to testdata/<name>, optional
+		wantWarn   int
+	}{
+		{
+			name: "nu",
+			opts: tsgen.Options{
+				Name:      "Nu",
+				Aliases:   []string{"nu"},
+				Filenames: []string{"*.nu"},
+				MimeTypes: []string{"text/plain"},
+			},
+			wantWarn: 2, // (comment) @comment has no literal pattern; (string_literal) @string needs a hand-written state
+		},
+		{
+			name: "json",
+			opts: tsgen.Options{
+				Name:      "JSON",
+				Aliases:   []string{"json"},
+				Filenames: []string{"*.json"},
+				MimeTypes: []string{"application/json"},
+			},
+			mappingYML: "mapping.yaml",
+			wantWarn:   1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := filepath.Join("testdata", tt.name)
+
+			grammar, err := tsgen.Load(dir)
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+
+			mapping := tsgen.DefaultCaptureMapping
+			if tt.mappingYML != "" {
+				mapping, err = tsgen.LoadOverrides(filepath.Join(dir, tt.mappingYML), mapping)
+				if err != nil {
+					t.Fatalf("LoadOverrides: %v", err)
+				}
+			}
+
+			result, err := tsgen.Generate(grammar, mapping, tt.opts)
+			if err != nil {
+				t.Fatalf("Generate: %v", err)
+			}
+			if len(result.Warnings) != tt.wantWarn {
+				t.Errorf("warnings = %v, want %d entries", result.Warnings, tt.wantWarn)
+			}
+
+			got, err := tsgen.Marshal(result.Lexer)
+			if err != nil {
+				t.Fatalf("Marshal: %v", err)
+			}
+
+			goldenPath := filepath.Join(dir, "golden.xml")
+			if *update {
+				if err := os.WriteFile(goldenPath, got, 0644); err != nil {
+					t.Fatalf("writing golden file: %v", err)
+				}
+				return
+			}
+
+			want, err := os.ReadFile(goldenPath)
+			if err != nil {
+				t.Fatalf("reading golden file: %v", err)
+			}
+			if string(got) != string(want) {
+				t.Errorf("generated XML for %s does not match %s\n--- got ---\n%s\n--- want ---\n%s",
+					tt.name, goldenPath, got, want)
+			}
+		})
+	}
+}
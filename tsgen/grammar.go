@@ -0,0 +1,101 @@
+package tsgen
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// Grammar is everything tsgen was able to recover from a tree-sitter
+// grammar checkout: the capture-annotated literals and node types from
+// highlights.scm, plus node-types.json when present.
+type Grammar struct {
+	// LiteralsByCapture holds every literal (keyword, operator, bracket,
+	// ...) a capture was attached to, deduplicated.
+	LiteralsByCapture map[string][]string
+	// NodeTypesByCapture holds named-node captures, e.g. "string" ->
+	// ["string_literal"].
+	NodeTypesByCapture map[string][]string
+
+	nodeTypes []NodeType
+}
+
+// Load reads highlights.scm (required) and node-types.json (optional) from
+// dir, which is expected to look like a tree-sitter-<lang> checkout. Each
+// file is looked for in the directory root first, then under queries/
+// (highlights.scm) or src/ (node-types.json).
+func Load(dir string) (*Grammar, error) {
+	highlights, err := findFile(dir, "highlights.scm", "queries/highlights.scm")
+	if err != nil {
+		return nil, err
+	}
+	src, err := os.ReadFile(highlights)
+	if err != nil {
+		return nil, fmt.Errorf("tsgen: reading %s: %w", highlights, err)
+	}
+
+	forms, err := parseQuery(string(src))
+	if err != nil {
+		return nil, fmt.Errorf("tsgen: parsing %s: %w", highlights, err)
+	}
+
+	g := &Grammar{
+		LiteralsByCapture:  map[string][]string{},
+		NodeTypesByCapture: map[string][]string{},
+	}
+	seenLit := map[string]map[string]bool{}
+	seenNode := map[string]map[string]bool{}
+	for _, c := range collectCaptures(forms) {
+		for _, lit := range c.literals {
+			if seenLit[c.name] == nil {
+				seenLit[c.name] = map[string]bool{}
+			}
+			if !seenLit[c.name][lit] {
+				seenLit[c.name][lit] = true
+				g.LiteralsByCapture[c.name] = append(g.LiteralsByCapture[c.name], lit)
+			}
+		}
+		if c.nodeType != "" {
+			if seenNode[c.name] == nil {
+				seenNode[c.name] = map[string]bool{}
+			}
+			if !seenNode[c.name][c.nodeType] {
+				seenNode[c.name][c.nodeType] = true
+				g.NodeTypesByCapture[c.name] = append(g.NodeTypesByCapture[c.name], c.nodeType)
+			}
+		}
+	}
+	for _, lits := range g.LiteralsByCapture {
+		sort.Strings(lits)
+	}
+	for _, nodes := range g.NodeTypesByCapture {
+		sort.Strings(nodes)
+	}
+
+	if nodeTypesPath, err := findFile(dir, "node-types.json", "src/node-types.json"); err == nil {
+		types, err := loadNodeTypes(nodeTypesPath)
+		if err != nil {
+			return nil, err
+		}
+		g.nodeTypes = types
+	}
+
+	return g, nil
+}
+
+// ChildTypes returns the named child node types node-types.json declares
+// for nodeType (nil if node-types.json wasn't found or doesn't mention it).
+func (g *Grammar) ChildTypes(nodeType string) []string {
+	return childTypes(g.nodeTypes, nodeType)
+}
+
+func findFile(dir string, candidates ...string) (string, error) {
+	for _, c := range candidates {
+		p := filepath.Join(dir, c)
+		if _, err := os.Stat(p); err == nil {
+			return p, nil
+		}
+	}
+	return "", fmt.Errorf("tsgen: none of %v found under %s", candidates, dir)
+}
@@ -0,0 +1,133 @@
+package tsgen
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/alecthomas/chroma/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// CaptureMapping maps a tree-sitter capture name, with its leading "@" and
+// any trailing "!" stripped, to the chroma.TokenType it should
+// emit. It is an alias for chroma.TreeSitterMapping so that a mapping built
+// here can be handed straight to chroma.NewTreeSitterLexer /
+// lexers.RegisterTreeSitter without conversion.
+type CaptureMapping = chroma.TreeSitterMapping
+
+// DefaultCaptureMapping covers the capture names that show up, near
+// verbatim, across most nvim-treesitter and tree-sitter grammar highlight
+// queries. Grammar-specific captures (or disagreements with these choices)
+// belong in a mapping-override YAML file passed to LoadOverrides.
+var DefaultCaptureMapping = CaptureMapping{
+	"keyword":               chroma.Keyword,
+	"keyword.control":       chroma.Keyword,
+	"keyword.operator":      chroma.Keyword,
+	"keyword.return":        chroma.Keyword,
+	"conditional":           chroma.Keyword,
+	"repeat":                chroma.Keyword,
+	"include":               chroma.KeywordNamespace,
+	"namespace":             chroma.KeywordNamespace,
+	"type":                  chroma.KeywordType,
+	"type.builtin":          chroma.KeywordType,
+	"storageclass":          chroma.KeywordType,
+	"constant":              chroma.NameConstant,
+	"constant.builtin":      chroma.KeywordConstant,
+	"boolean":               chroma.KeywordConstant,
+	"function":              chroma.NameFunction,
+	"function.call":         chroma.NameFunction,
+	"function.builtin":      chroma.NameBuiltin,
+	"function.macro":        chroma.NameFunction,
+	"method":                chroma.NameFunction,
+	"method.call":           chroma.NameFunction,
+	"variable":              chroma.NameVariable,
+	"variable.builtin":      chroma.NameBuiltin,
+	"variable.parameter":    chroma.NameVariable,
+	"parameter":             chroma.NameVariable,
+	"property":              chroma.NameAttribute,
+	"field":                 chroma.NameAttribute,
+	"attribute":             chroma.NameAttribute,
+	"label":                 chroma.NameLabel,
+	"number":                chroma.LiteralNumber,
+	"float":                 chroma.LiteralNumber,
+	"string":                chroma.LiteralString,
+	"string.special":        chroma.LiteralStringInterpol,
+	"string.escape":         chroma.LiteralStringEscape,
+	"string.regexp":         chroma.LiteralStringRegex,
+	"character":             chroma.LiteralStringChar,
+	"comment":               chroma.CommentSingle,
+	"comment.block":         chroma.CommentMultiline,
+	"comment.documentation": chroma.CommentSingle,
+	"operator":              chroma.Operator,
+	"punctuation":           chroma.Punctuation,
+	"punctuation.bracket":   chroma.Punctuation,
+	"punctuation.delimiter": chroma.Punctuation,
+	"punctuation.special":   chroma.Punctuation,
+	"embedded":              chroma.LiteralStringInterpol,
+	"none":                  chroma.Text,
+}
+
+// tokenTypeByName resolves the subset of chroma.TokenType names that are
+// legal targets for a mapping-override file. It is deliberately small: a
+// grammar that needs a TokenType not listed here should be extended inline
+// rather than widening what overrides accept.
+var tokenTypeByName = map[string]chroma.TokenType{
+	"Keyword":               chroma.Keyword,
+	"KeywordConstant":       chroma.KeywordConstant,
+	"KeywordNamespace":      chroma.KeywordNamespace,
+	"KeywordType":           chroma.KeywordType,
+	"NameFunction":          chroma.NameFunction,
+	"NameBuiltin":           chroma.NameBuiltin,
+	"NameVariable":          chroma.NameVariable,
+	"NameAttribute":         chroma.NameAttribute,
+	"NameConstant":          chroma.NameConstant,
+	"NameLabel":             chroma.NameLabel,
+	"LiteralNumber":         chroma.LiteralNumber,
+	"LiteralString":         chroma.LiteralString,
+	"LiteralStringDouble":   chroma.LiteralStringDouble,
+	"LiteralStringSingle":   chroma.LiteralStringSingle,
+	"LiteralStringChar":     chroma.LiteralStringChar,
+	"LiteralStringEscape":   chroma.LiteralStringEscape,
+	"LiteralStringInterpol": chroma.LiteralStringInterpol,
+	"LiteralStringRegex":    chroma.LiteralStringRegex,
+	"CommentSingle":         chroma.CommentSingle,
+	"CommentMultiline":      chroma.CommentMultiline,
+	"CommentHashbang":       chroma.CommentHashbang,
+	"Operator":              chroma.Operator,
+	"Punctuation":           chroma.Punctuation,
+	"Text":                  chroma.Text,
+	"TextWhitespace":        chroma.TextWhitespace,
+}
+
+// LoadOverrides reads a YAML file mapping capture names to chroma.TokenType
+// names, e.g.:
+//
+//	string.special: LiteralStringInterpol
+//	constant.builtin: NameBuiltin
+//
+// and returns base with those entries merged in (the override wins on
+// conflict). base is never mutated.
+func LoadOverrides(path string, base CaptureMapping) (CaptureMapping, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("tsgen: reading mapping overrides: %w", err)
+	}
+
+	var overrides map[string]string
+	if err := yaml.Unmarshal(raw, &overrides); err != nil {
+		return nil, fmt.Errorf("tsgen: parsing mapping overrides %s: %w", path, err)
+	}
+
+	merged := make(CaptureMapping, len(base)+len(overrides))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for capture, typeName := range overrides {
+		tt, ok := tokenTypeByName[typeName]
+		if !ok {
+			return nil, fmt.Errorf("tsgen: mapping override %s: unknown token type %q", path, typeName)
+		}
+		merged[capture] = tt
+	}
+	return merged, nil
+}
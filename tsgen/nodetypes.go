@@ -0,0 +1,56 @@
+package tsgen
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// NodeType is the subset of a tree-sitter node-types.json entry tsgen
+// cares about: whether a grammar has a given named node at all, and what
+// named children it declares, which Generate uses to flag captures that
+// need a hand-written lexer state it can't synthesize on its own.
+type NodeType struct {
+	Type     string `json:"type"`
+	Named    bool   `json:"named"`
+	Children *struct {
+		Types []struct {
+			Type string `json:"type"`
+		} `json:"types"`
+	} `json:"children,omitempty"`
+}
+
+// loadNodeTypes reads a grammar's node-types.json. It is optional input:
+// grammars that don't ship one still generate a lexer from highlights.scm
+// alone, just without the extra escape/interpolation state detection
+// childTypes enables. One that is present but can't be read or parsed is
+// an error, and Load returns it.
+func loadNodeTypes(path string) ([]NodeType, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("tsgen: reading node-types.json: %w", err)
+	}
+	var types []NodeType
+	if err := json.Unmarshal(raw, &types); err != nil {
+		return nil, fmt.Errorf("tsgen: parsing node-types.json: %w", err)
+	}
+	return types, nil
+}
+
+// childTypes returns the named child node types declared for nodeType,
+// e.g. "string" -> ["escape_sequence", "interpolation"]. Generate uses
+// this only to warn that a node needs a hand-written push/pop state; it
+// doesn't attempt to derive that state's patterns automatically.
+func childTypes(types []NodeType, nodeType string) []string {
+	for _, t := range types {
+		if t.Type != nodeType || t.Children == nil {
+			continue
+		}
+		var names []string
+		for _, c := range t.Children.Types {
+			names = append(names, c.Type)
+		}
+		return names
+	}
+	return nil
+}
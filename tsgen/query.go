@@ -0,0 +1,190 @@
+package tsgen
+
+import (
+	"fmt"
+	"strings"
+)
+
+// queryNode is a minimal parse of one S-expression out of a tree-sitter
+// query file (highlights.scm). It understands just enough of the query
+// grammar to recover @capture annotations: lists, bracketed alternations,
+// string literals and bare symbols. Predicates (`#eq?`, `#match?`, ...),
+// field names (`name:`) and quantifiers (`*`, `+`, `?`) are parsed but not
+// otherwise interpreted.
+type queryNode struct {
+	kind     queryKind
+	value    string // for kindString/kindSymbol
+	children []*queryNode
+}
+
+type queryKind int
+
+const (
+	kindList queryKind = iota
+	kindBracket
+	kindString
+	kindSymbol
+)
+
+// parseQuery parses the top-level forms of a highlights.scm file and
+// returns them as a flat sequence, in source order. Captures are returned
+// as their own symbol nodes (e.g. "@keyword") immediately following the
+// form they annotate, matching how collectCaptures expects to consume them.
+func parseQuery(src string) ([]*queryNode, error) {
+	toks := tokenizeQuery(src)
+	var nodes []*queryNode
+	for len(toks) > 0 {
+		var n *queryNode
+		var err error
+		n, toks, err = parseForm(toks)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, n)
+	}
+	return nodes, nil
+}
+
+func parseForm(toks []string) (*queryNode, []string, error) {
+	if len(toks) == 0 {
+		return nil, nil, fmt.Errorf("tsgen: unexpected end of query")
+	}
+	tok := toks[0]
+	switch {
+	case tok == "(":
+		return parseSeq(toks[1:], ")", kindList)
+	case tok == "[":
+		return parseSeq(toks[1:], "]", kindBracket)
+	case strings.HasPrefix(tok, `"`):
+		return &queryNode{kind: kindString, value: strings.Trim(tok, `"`)}, toks[1:], nil
+	default:
+		return &queryNode{kind: kindSymbol, value: tok}, toks[1:], nil
+	}
+}
+
+func parseSeq(toks []string, closer string, kind queryKind) (*queryNode, []string, error) {
+	n := &queryNode{kind: kind}
+	for {
+		if len(toks) == 0 {
+			return nil, nil, fmt.Errorf("tsgen: unterminated %q in query", closer)
+		}
+		if toks[0] == closer {
+			return n, toks[1:], nil
+		}
+		var child *queryNode
+		var err error
+		child, toks, err = parseForm(toks)
+		if err != nil {
+			return nil, nil, err
+		}
+		n.children = append(n.children, child)
+	}
+}
+
+// tokenizeQuery splits a highlights.scm file into parens, brackets, quoted
+// strings (with escapes) and bare symbols, dropping ";"-led comments.
+func tokenizeQuery(src string) []string {
+	var toks []string
+	runes := []rune(src)
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+		switch {
+		case c == ';':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			continue
+		case c == '(' || c == ')' || c == '[' || c == ']':
+			toks = append(toks, string(c))
+		case c == '"':
+			start := i
+			i++
+			for i < len(runes) && runes[i] != '"' {
+				if runes[i] == '\\' {
+					i++
+				}
+				i++
+			}
+			toks = append(toks, string(runes[start:i+1]))
+		default:
+			start := i
+			for i < len(runes) && !strings.ContainsRune(" \t\n\r()[]", runes[i]) {
+				i++
+			}
+			toks = append(toks, string(runes[start:i]))
+			i--
+		}
+	}
+	return toks
+}
+
+// capture is one @name annotation recovered from a highlights.scm file,
+// together with the literal words or node type it was attached to.
+type capture struct {
+	name     string   // e.g. "string.escape", without the leading "@"
+	literals []string // literal terms, for string/bracket-of-string forms
+	nodeType string   // named node type, for "(node_type) @capture" forms
+}
+
+// collectCaptures walks the flat form sequence produced by parseQuery and
+// pairs each pattern with the @capture symbol that immediately follows it.
+// Predicate forms ("(#eq? ...)") and bare captures with no preceding
+// pattern are ignored.
+func collectCaptures(nodes []*queryNode) []capture {
+	var captures []capture
+	for i := 0; i < len(nodes)-1; i++ {
+		form, next := nodes[i], nodes[i+1]
+		if next.kind != kindSymbol || !strings.HasPrefix(next.value, "@") {
+			continue
+		}
+		name := strings.TrimSuffix(strings.TrimPrefix(next.value, "@"), "!")
+
+		switch form.kind {
+		case kindString:
+			captures = append(captures, capture{name: name, literals: []string{form.value}})
+		case kindBracket:
+			var lits []string
+			for _, c := range form.children {
+				if c.kind == kindString {
+					lits = append(lits, c.value)
+				}
+			}
+			if len(lits) > 0 {
+				captures = append(captures, capture{name: name, literals: lits})
+			}
+		case kindList:
+			if isPredicate(form) {
+				continue
+			}
+			if nodeType := namedNodeType(form); nodeType != "" {
+				captures = append(captures, capture{name: name, nodeType: nodeType})
+			}
+		}
+	}
+	return captures
+}
+
+// isPredicate reports whether a list form is a query predicate such as
+// (#eq? @cap "text") rather than a syntax pattern.
+func isPredicate(n *queryNode) bool {
+	return len(n.children) > 0 && n.children[0].kind == kindSymbol && strings.HasPrefix(n.children[0].value, "#")
+}
+
+// namedNodeType returns the node type a pattern list matches: its first
+// bare symbol, with any trailing ":" trimmed, skipping the wildcard "_".
+// Nested lists are not searched, so a field name such as "name:" is
+// returned as "name" rather than the type of the pattern that follows it.
+func namedNodeType(n *queryNode) string {
+	for _, c := range n.children {
+		if c.kind != kindSymbol {
+			continue
+		}
+		t := strings.TrimSuffix(c.value, ":")
+		if t == "_" || t == "" {
+			continue
+		}
+		return t
+	}
+	return ""
+}
@@ -0,0 +1,76 @@
+package tsgen
+
+import (
+	"encoding/xml"
+	"strings"
+)
+
+// Lexer mirrors the shape chroma's regex lexer loader expects from
+// lexers/embedded/*.xml.
+type Lexer struct {
+	XMLName xml.Name `xml:"lexer"`
+	Config  Config   `xml:"config"`
+	Rules   Rules    `xml:"rules"`
+}
+
+// Config is the <config> block: the bits of chroma.Config that matter for
+// lexer selection (name, aliases, filenames, mime types).
+type Config struct {
+	Name      string   `xml:"name"`
+	Aliases   []string `xml:"alias"`
+	Filenames []string `xml:"filename"`
+	MimeTypes []string `xml:"mime_type"`
+}
+
+// Rules is the <rules> block, a flat list of named states.
+type Rules struct {
+	States []State `xml:"state"`
+}
+
+// State is a single named state and the ordered rules tried against it.
+type State struct {
+	Name  string `xml:"name,attr"`
+	Rules []Rule `xml:"rule"`
+}
+
+// Rule is one pattern/action pair within a state. Exactly one of Token,
+// ByGroups should be set; Include is mutually exclusive with everything
+// else.
+type Rule struct {
+	Pattern  string    `xml:"pattern,attr,omitempty"`
+	Include  string    `xml:"include,attr,omitempty"`
+	Push     string    `xml:"push,attr,omitempty"`
+	Pop      int       `xml:"pop,attr,omitempty"`
+	Token    *Token    `xml:"token,omitempty"`
+	ByGroups *ByGroups `xml:"bygroups,omitempty"`
+}
+
+// Token names a single chroma.TokenType by its string form, e.g. "Keyword".
+type Token struct {
+	Type string `xml:"type,attr"`
+}
+
+// ByGroups assigns a TokenType to each capture group of a Rule's pattern, in
+// order.
+type ByGroups struct {
+	Tokens []Token `xml:"token"`
+}
+
+// Marshal renders a Lexer as a formatted, self-contained XML document ready
+// to be written under lexers/embedded/.
+func Marshal(lexer *Lexer) ([]byte, error) {
+	body, err := xml.MarshalIndent(lexer, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	out := `<?xml version="1.0" encoding="UTF-8"?>` + "\n" + string(body) + "\n"
+
+	// encoding/xml escapes ">" even inside attribute values, where XML
+	// allows it literally; un-escape it so regex patterns read more
+	// clearly. "<" and "&" stay escaped, as lexers/embedded files keep
+	// them, since the XML would not parse otherwise.
+	out = strings.ReplaceAll(out, "&gt;", ">")
+
+	return []byte(out), nil
+}